@@ -0,0 +1,107 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExistingPasswordSecret is a reference to a Secret key already present in
+// the cluster, used instead of provisioning a new one.
+type ExistingPasswordSecret struct {
+	Name *string `json:"name,omitempty"`
+	Key  *string `json:"key,omitempty"`
+}
+
+// KubernetesConfig holds cluster-wide Kubernetes-level settings, such as
+// where to find the Redis AUTH password.
+type KubernetesConfig struct {
+	ExistingPasswordSecret *ExistingPasswordSecret `json:"existingPasswordSecret,omitempty"`
+}
+
+// SentinelConfig enables a Sentinel-monitored deployment alongside the
+// leader/follower StatefulSets, for workloads that failover via Sentinel
+// rather than native cluster mode.
+type SentinelConfig struct {
+	// MasterName is the name Sentinel uses to identify the monitored master.
+	MasterName string `json:"masterName"`
+	// Replicas is the number of Sentinel pods to run.
+	Replicas int32 `json:"replicas"`
+	// Quorum is the number of Sentinels that must agree a master is down
+	// before a failover is started.
+	Quorum int32 `json:"quorum"`
+}
+
+// RecoverySpec configures RecoveryReconciler's auto-recovery policy. It only
+// takes effect on CRs annotated redis.udaan.com/auto-recover=true.
+type RecoverySpec struct {
+	// QuorumLossTimeout is how long more than half the masters must report
+	// fail/disconnected before a recovery attempt is triggered.
+	QuorumLossTimeout metav1.Duration `json:"quorumLossTimeout,omitempty"`
+	// MaxRecoveryAttempts bounds how many recovery attempts may be made
+	// within the circuit breaker's rolling window. Defaults to a safe,
+	// non-zero value when unset so enabling auto-recovery never implies
+	// unlimited retries.
+	MaxRecoveryAttempts int32 `json:"maxRecoveryAttempts,omitempty"`
+}
+
+// RedisClusterSpec defines the desired state of a RedisCluster.
+type RedisClusterSpec struct {
+	// Size is the number of leader pods, mirrored 1:1 by an equal number of
+	// follower pods.
+	Size int32 `json:"size"`
+
+	KubernetesConfig KubernetesConfig `json:"kubernetesConfig,omitempty"`
+
+	// SentinelConfig, if set, runs a Sentinel StatefulSet monitoring this
+	// cluster's leaders alongside native cluster mode.
+	SentinelConfig *SentinelConfig `json:"sentinelConfig,omitempty"`
+
+	// Recovery configures the auto-recovery controller's policy.
+	Recovery RecoverySpec `json:"recovery,omitempty"`
+}
+
+// GetReplicaCounts returns the configured replica count for role, one of
+// "leader", "follower" or "sentinel".
+func (s RedisClusterSpec) GetReplicaCounts(role string) int32 {
+	switch role {
+	case "leader", "follower":
+		return s.Size
+	case "sentinel":
+		if s.SentinelConfig != nil {
+			return s.SentinelConfig.Replicas
+		}
+	}
+	return 0
+}
+
+// RedisClusterStatus defines the observed state of a RedisCluster.
+type RedisClusterStatus struct {
+	// RebalanceProgress records the hash slots RebalanceShards has already
+	// migrated, so a crashed reconcile can resume instead of restarting the
+	// whole rebalance plan.
+	RebalanceProgress []int `json:"rebalanceProgress,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// RedisCluster is the Schema for the redisclusters API.
+type RedisCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisClusterSpec   `json:"spec,omitempty"`
+	Status RedisClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RedisClusterList contains a list of RedisCluster.
+type RedisClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedisCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedisCluster{}, &RedisClusterList{})
+}
@@ -0,0 +1,178 @@
+package k8sutils
+
+import (
+	"fmt"
+	"strconv"
+
+	redisv1beta1 "redis-operator/api/v1beta1"
+
+	"github.com/go-redis/redis"
+)
+
+// totalRedisSlots is the fixed slot space every Redis Cluster must cover.
+const totalRedisSlots = 16384
+
+// SlotAssignment describes the contiguous hash slot range owned by a
+// single leader, identified by its pod index within the leader StatefulSet.
+type SlotAssignment struct {
+	LeaderIndex int
+	Start       int
+	End         int
+}
+
+// ClusterManager drives cluster formation over a pooled redis.ClusterClient
+// instead of shelling out to redis-cli, so meet/addslots/replicate become
+// structured, retryable redis.Cmd calls with real errors instead of parsed
+// CLI output.
+type ClusterManager struct {
+	cr     *redisv1beta1.RedisCluster
+	client *redis.ClusterClient
+}
+
+// NewClusterManager builds a ClusterManager seeded with the leader pods of cr.
+func NewClusterManager(cr *redisv1beta1.RedisCluster) *ClusterManager {
+	return &ClusterManager{
+		cr:     cr,
+		client: configureRedisClusterClient(cr),
+	}
+}
+
+// configureRedisClusterClient builds a *redis.ClusterClient seeded with the
+// addresses of every leader pod, for cluster-wide operations such as slot
+// inspection, node listing and forgets.
+func configureRedisClusterClient(cr *redisv1beta1.RedisCluster) *redis.ClusterClient {
+	replicas := cr.Spec.GetReplicaCounts("leader")
+	addrs := make([]string, 0, replicas)
+	for podCount := 0; podCount <= int(replicas)-1; podCount++ {
+		pod := RedisDetails{
+			PodName:   cr.ObjectMeta.Name + "-leader-" + strconv.Itoa(podCount),
+			Namespace: cr.Namespace,
+		}
+		addrs = append(addrs, getRedisServerIP(pod)+":6379")
+	}
+
+	options := &redis.ClusterOptions{
+		Addrs:     addrs,
+		TLSConfig: getRedisTLSConfig(cr, RedisDetails{PodName: cr.ObjectMeta.Name + "-leader-0", Namespace: cr.Namespace}),
+	}
+	if cr.Spec.KubernetesConfig.ExistingPasswordSecret != nil {
+		logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+		pass, err := getRedisPassword(cr.Namespace, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Name, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Key)
+		if err != nil {
+			logger.Error(err, "Error in getting redis password")
+		}
+		options.Password = pass
+	}
+	return redis.NewClusterClient(options)
+}
+
+// ComputeSlotRanges deterministically divides the 16384 hash slots across
+// leaderCount leaders, handing any remainder to the earliest leaders so the
+// assignment is stable and reproducible across reconciles.
+func ComputeSlotRanges(leaderCount int) []SlotAssignment {
+	assignments := make([]SlotAssignment, 0, leaderCount)
+	base := totalRedisSlots / leaderCount
+	remainder := totalRedisSlots % leaderCount
+
+	next := 0
+	for i := 0; i < leaderCount; i++ {
+		size := base
+		if i < remainder {
+			size++
+		}
+		assignments = append(assignments, SlotAssignment{
+			LeaderIndex: i,
+			Start:       next,
+			End:         next + size - 1,
+		})
+		next += size
+	}
+	return assignments
+}
+
+// MeetNodes issues CLUSTER MEET from the first leader to every other leader
+// pod, forming a single cluster bus without needing pods/exec RBAC.
+func (cm *ClusterManager) MeetNodes() error {
+	replicas := cm.cr.Spec.GetReplicaCounts("leader")
+	for podCount := 1; podCount <= int(replicas)-1; podCount++ {
+		podName := cm.cr.ObjectMeta.Name + "-leader-" + strconv.Itoa(podCount)
+		if err := cm.MeetNode(podName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MeetNode issues CLUSTER MEET from the existing cluster to podName's IP, so
+// a pod that has never gossiped with the cluster (a freshly started follower,
+// or a leader added by a scale-out) is known to every other node before any
+// CLUSTER REPLICATE/SETSLOT call references its node ID.
+func (cm *ClusterManager) MeetNode(podName string) error {
+	logger := generateRedisManagerLogger(cm.cr.Namespace, cm.cr.ObjectMeta.Name)
+	pod := RedisDetails{
+		PodName:   podName,
+		Namespace: cm.cr.Namespace,
+	}
+	ip := getRedisServerIP(pod)
+	cmd := redis.NewStringCmd("cluster", "meet", ip, "6379")
+	if err := cm.client.Process(cmd); err != nil {
+		logger.Error(err, "CLUSTER MEET failed", "Pod", podName)
+		return fmt.Errorf("cluster meet %s failed: %w", podName, err)
+	}
+	return nil
+}
+
+// AssignSlots computes a deterministic slot distribution for the current
+// leader count and assigns each range to its leader via CLUSTER ADDSLOTSRANGE.
+func (cm *ClusterManager) AssignSlots() error {
+	logger := generateRedisManagerLogger(cm.cr.Namespace, cm.cr.ObjectMeta.Name)
+	leaderCount := int(cm.cr.Spec.GetReplicaCounts("leader"))
+	assignments := ComputeSlotRanges(leaderCount)
+
+	for _, assignment := range assignments {
+		pod := RedisDetails{
+			PodName:   cm.cr.ObjectMeta.Name + "-leader-" + strconv.Itoa(assignment.LeaderIndex),
+			Namespace: cm.cr.Namespace,
+		}
+		client := configureRedisClient(cm.cr, pod.PodName)
+		cmd := redis.NewStringCmd("cluster", "addslotsrange", assignment.Start, assignment.End)
+		if err := client.Process(cmd); err != nil {
+			logger.Error(err, "CLUSTER ADDSLOTSRANGE failed", "Pod", pod.PodName, "Start", assignment.Start, "End", assignment.End)
+			return fmt.Errorf("cluster addslotsrange %d-%d on %s failed: %w", assignment.Start, assignment.End, pod.PodName, err)
+		}
+		logger.Info("Assigned slot range", "Pod", pod.PodName, "Start", assignment.Start, "End", assignment.End)
+	}
+	return nil
+}
+
+// NodeID returns the node ID reported by CLUSTER MYID for the given pod.
+func (cm *ClusterManager) NodeID(podName string) (string, error) {
+	client := configureRedisClient(cm.cr, podName)
+	cmd := redis.NewStringCmd("cluster", "myid")
+	if err := client.Process(cmd); err != nil {
+		return "", fmt.Errorf("cluster myid on %s failed: %w", podName, err)
+	}
+	return cmd.Result()
+}
+
+// Replicate issues CLUSTER REPLICATE on followerPodName so it becomes a
+// replica of the leader identified by leaderNodeID.
+func (cm *ClusterManager) Replicate(followerPodName, leaderNodeID string) error {
+	logger := generateRedisManagerLogger(cm.cr.Namespace, cm.cr.ObjectMeta.Name)
+	client := configureRedisClient(cm.cr, followerPodName)
+	cmd := redis.NewStringCmd("cluster", "replicate", leaderNodeID)
+	if err := client.Process(cmd); err != nil {
+		logger.Error(err, "CLUSTER REPLICATE failed", "Pod", followerPodName, "Leader", leaderNodeID)
+		return fmt.Errorf("cluster replicate %s -> %s failed: %w", followerPodName, leaderNodeID, err)
+	}
+	return nil
+}
+
+// CreateCluster meets every leader together and assigns slots, replacing the
+// former `redis-cli --cluster create` shell-out.
+func (cm *ClusterManager) CreateCluster() error {
+	if err := cm.MeetNodes(); err != nil {
+		return err
+	}
+	return cm.AssignSlots()
+}
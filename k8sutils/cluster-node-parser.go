@@ -0,0 +1,199 @@
+package k8sutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SlotRange is a contiguous, inclusive range of hash slots owned by a node,
+// as reported by a CLUSTER NODES slot token such as "0-5460" or a bare
+// "5461" for a single slot.
+type SlotRange struct {
+	Start int
+	End   int
+}
+
+// ClusterNode is a single parsed line of CLUSTER NODES output, per the line
+// grammar documented at https://redis.io/docs/management/scaling/#cluster-nodes:
+//
+//	<id> <ip:port@cport> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+type ClusterNode struct {
+	ID          string
+	Addr        string
+	BusPort     string
+	Flags       []string
+	Master      string
+	PingSent    int64
+	PongRecv    int64
+	ConfigEpoch int64
+	LinkState   string
+	Slots       []SlotRange
+	Migrating   map[int]string
+	Importing   map[int]string
+}
+
+// IsMaster reports whether node carries the "master" flag.
+func (n ClusterNode) IsMaster() bool {
+	return n.hasFlag("master")
+}
+
+// IsSlave reports whether node carries the "slave" flag.
+func (n ClusterNode) IsSlave() bool {
+	return n.hasFlag("slave")
+}
+
+// IsFailed reports whether node carries the "fail" or "fail?" flag.
+func (n ClusterNode) IsFailed() bool {
+	return n.hasFlag("fail") || n.hasFlag("fail?")
+}
+
+// IsMyself reports whether node is the node CLUSTER NODES was queried on.
+func (n ClusterNode) IsMyself() bool {
+	return n.hasFlag("myself")
+}
+
+func (n ClusterNode) hasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClusterNodes parses the raw output of CLUSTER NODES into a typed
+// slice. Unlike a naive space-delimited CSV split, it correctly handles
+// IPv6 bracketed addresses, multi-flag combinations such as
+// "myself,master,fail?", and migrating/importing slot markers.
+func ParseClusterNodes(raw string) ([]ClusterNode, error) {
+	var nodes []ClusterNode
+	for _, line := range strings.Split(strings.TrimSpace(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		node, err := parseClusterNodeLine(line)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func parseClusterNodeLine(line string) (ClusterNode, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 8 {
+		return ClusterNode{}, fmt.Errorf("malformed cluster nodes line: %q", line)
+	}
+
+	addr, busPort := splitAddrBusPort(fields[1])
+
+	pingSent, err := strconv.ParseInt(fields[4], 10, 64)
+	if err != nil {
+		return ClusterNode{}, fmt.Errorf("parsing ping-sent in %q: %w", line, err)
+	}
+	pongRecv, err := strconv.ParseInt(fields[5], 10, 64)
+	if err != nil {
+		return ClusterNode{}, fmt.Errorf("parsing pong-recv in %q: %w", line, err)
+	}
+	configEpoch, err := strconv.ParseInt(fields[6], 10, 64)
+	if err != nil {
+		return ClusterNode{}, fmt.Errorf("parsing config-epoch in %q: %w", line, err)
+	}
+
+	node := ClusterNode{
+		ID:          fields[0],
+		Addr:        addr,
+		BusPort:     busPort,
+		Flags:       strings.Split(fields[2], ","),
+		Master:      fields[3],
+		PingSent:    pingSent,
+		PongRecv:    pongRecv,
+		ConfigEpoch: configEpoch,
+		LinkState:   fields[7],
+	}
+
+	for _, tok := range fields[8:] {
+		switch {
+		case strings.HasPrefix(tok, "[") && strings.Contains(tok, "->-"):
+			slot, dest, err := parseSlotMarker(tok, "->-")
+			if err != nil {
+				return ClusterNode{}, err
+			}
+			if node.Migrating == nil {
+				node.Migrating = make(map[int]string)
+			}
+			node.Migrating[slot] = dest
+		case strings.HasPrefix(tok, "[") && strings.Contains(tok, "-<-"):
+			slot, src, err := parseSlotMarker(tok, "-<-")
+			if err != nil {
+				return ClusterNode{}, err
+			}
+			if node.Importing == nil {
+				node.Importing = make(map[int]string)
+			}
+			node.Importing[slot] = src
+		default:
+			slotRange, err := parseSlotRange(tok)
+			if err != nil {
+				return ClusterNode{}, err
+			}
+			node.Slots = append(node.Slots, slotRange)
+		}
+	}
+
+	return node, nil
+}
+
+// splitAddrBusPort separates the "ip:port@busport" (or bracketed
+// "[ipv6]:port@busport", optionally followed by ",hostname") address token
+// CLUSTER NODES reports into its client address and cluster bus port.
+func splitAddrBusPort(token string) (addr string, busPort string) {
+	addr = token
+	if at := strings.LastIndex(token, "@"); at >= 0 {
+		addr = token[:at]
+		busPort = token[at+1:]
+	}
+	if comma := strings.IndexByte(busPort, ','); comma >= 0 {
+		busPort = busPort[:comma]
+	}
+	return addr, busPort
+}
+
+// parseSlotRange parses a bare slot token such as "0-5460" or "5461".
+func parseSlotRange(tok string) (SlotRange, error) {
+	if dash := strings.IndexByte(tok, '-'); dash > 0 {
+		start, err := strconv.Atoi(tok[:dash])
+		if err != nil {
+			return SlotRange{}, fmt.Errorf("parsing slot range %q: %w", tok, err)
+		}
+		end, err := strconv.Atoi(tok[dash+1:])
+		if err != nil {
+			return SlotRange{}, fmt.Errorf("parsing slot range %q: %w", tok, err)
+		}
+		return SlotRange{Start: start, End: end}, nil
+	}
+	slot, err := strconv.Atoi(tok)
+	if err != nil {
+		return SlotRange{}, fmt.Errorf("parsing slot %q: %w", tok, err)
+	}
+	return SlotRange{Start: slot, End: slot}, nil
+}
+
+// parseSlotMarker parses a migrating/importing slot token of the form
+// "[<slot><sep><node-id>]", e.g. "[1000->-abcd1234]" while migrating or
+// "[1000-<-abcd1234]" while importing.
+func parseSlotMarker(tok, sep string) (int, string, error) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(tok, "["), "]")
+	parts := strings.SplitN(inner, sep, 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("malformed slot marker %q", tok)
+	}
+	slot, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("parsing slot marker %q: %w", tok, err)
+	}
+	return slot, parts[1], nil
+}
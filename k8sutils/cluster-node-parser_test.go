@@ -0,0 +1,107 @@
+package k8sutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseClusterNodes(t *testing.T) {
+	raw := `07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004 slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected
+e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca [::1]:30001@31001 myself,master - 0 0 1 connected 0-5460
+67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 127.0.0.1:30002@31002 master,fail? - 0 1426238316232 2 connected 5461-10922
+292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 127.0.0.1:30003@31003 master - 0 1426238317741 3 connected 10923-16379 [16380->-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1]`
+
+	nodes, err := ParseClusterNodes(raw)
+	if err != nil {
+		t.Fatalf("ParseClusterNodes returned error: %v", err)
+	}
+	if len(nodes) != 4 {
+		t.Fatalf("expected 4 nodes, got %d", len(nodes))
+	}
+
+	slave := nodes[0]
+	if !slave.IsSlave() || slave.IsMaster() {
+		t.Errorf("node 0: expected slave flag only, got %v", slave.Flags)
+	}
+	if slave.Master != "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca" {
+		t.Errorf("node 0: unexpected master id %q", slave.Master)
+	}
+
+	ipv6 := nodes[1]
+	if ipv6.Addr != "[::1]:30001" {
+		t.Errorf("node 1: expected bracketed IPv6 addr, got %q", ipv6.Addr)
+	}
+	if !ipv6.IsMyself() || !ipv6.IsMaster() {
+		t.Errorf("node 1: expected myself,master flags, got %v", ipv6.Flags)
+	}
+	wantSlots := []SlotRange{{Start: 0, End: 5460}}
+	if !reflect.DeepEqual(ipv6.Slots, wantSlots) {
+		t.Errorf("node 1: expected slots %v, got %v", wantSlots, ipv6.Slots)
+	}
+
+	multiFlag := nodes[2]
+	if !multiFlag.IsMaster() || !multiFlag.IsFailed() {
+		t.Errorf("node 2: expected master,fail? flags, got %v", multiFlag.Flags)
+	}
+
+	migrating := nodes[3]
+	wantMigrating := map[int]string{16380: "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1"}
+	if !reflect.DeepEqual(migrating.Migrating, wantMigrating) {
+		t.Errorf("node 3: expected migrating %v, got %v", wantMigrating, migrating.Migrating)
+	}
+}
+
+func TestParseClusterNodesImporting(t *testing.T) {
+	raw := `292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 127.0.0.1:30003@31003 myself,master - 0 1426238317741 3 connected 10923-16379 [16380-<-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1]`
+
+	nodes, err := ParseClusterNodes(raw)
+	if err != nil {
+		t.Fatalf("ParseClusterNodes returned error: %v", err)
+	}
+	if len(nodes) != 1 {
+		t.Fatalf("expected 1 node, got %d", len(nodes))
+	}
+
+	wantImporting := map[int]string{16380: "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1"}
+	if !reflect.DeepEqual(nodes[0].Importing, wantImporting) {
+		t.Errorf("expected importing %v, got %v", wantImporting, nodes[0].Importing)
+	}
+}
+
+func TestParseClusterNodesMalformedLine(t *testing.T) {
+	if _, err := ParseClusterNodes("not-enough-fields"); err == nil {
+		t.Fatal("expected error for malformed line, got nil")
+	}
+}
+
+func TestParseSlotMarker(t *testing.T) {
+	tests := []struct {
+		name     string
+		tok      string
+		sep      string
+		wantSlot int
+		wantNode string
+		wantErr  bool
+	}{
+		{name: "migrating", tok: "[1000->-abcd1234]", sep: "->-", wantSlot: 1000, wantNode: "abcd1234"},
+		{name: "importing", tok: "[1000-<-abcd1234]", sep: "-<-", wantSlot: 1000, wantNode: "abcd1234"},
+		{name: "malformed", tok: "[1000]", sep: "->-", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, node, err := parseSlotMarker(tt.tok, tt.sep)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if slot != tt.wantSlot || node != tt.wantNode {
+				t.Errorf("got (%d, %q), want (%d, %q)", slot, node, tt.wantSlot, tt.wantNode)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	redisv1beta1 "redis-operator/api/v1beta1"
+
+	"github.com/go-redis/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// healthCheckInterval is how often the background health-checker polls every
+// known RedisCluster for its current cluster and node state.
+const healthCheckInterval = 15 * time.Second
+
+// NodeHealth is the per-node snapshot collected from PING and INFO
+// replication against a single pod.
+type NodeHealth struct {
+	PodName          string   `json:"podName"`
+	Addr             string   `json:"addr"`
+	Role             string   `json:"role"`
+	Up               bool     `json:"up"`
+	Slots            []string `json:"slots,omitempty"`
+	MasterReplOffset int64    `json:"masterReplOffset"`
+	SlaveReplOffset  int64    `json:"slaveReplOffset,omitempty"`
+	ReplicationLag   int64    `json:"replicationLag"`
+}
+
+// ClusterHealth is the cached, point-in-time health snapshot for a single
+// RedisCluster, served over GET /cluster/{ns}/{name}/health.
+type ClusterHealth struct {
+	ClusterState   string       `json:"clusterState"`
+	ClusterSlotsOk int          `json:"clusterSlotsOk"`
+	SlotsAssigned  int          `json:"slotsAssigned"`
+	Nodes          []NodeHealth `json:"nodes"`
+	CheckedAt      time.Time    `json:"checkedAt"`
+}
+
+var (
+	redisClusterStateMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_cluster_state",
+		Help: "Whether the redis cluster reports cluster_state:ok (1) or not (0)",
+	}, []string{"namespace", "cluster"})
+
+	redisClusterSlotsAssignedMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_cluster_slots_assigned",
+		Help: "Number of hash slots currently assigned in the cluster",
+	}, []string{"namespace", "cluster"})
+
+	redisNodeUpMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_node_up",
+		Help: "Whether a redis node responded to PING (1) or not (0)",
+	}, []string{"namespace", "cluster", "pod"})
+
+	redisReplicationOffsetLagMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_replication_offset_lag",
+		Help: "Replication offset lag in bytes between a follower and its master",
+	}, []string{"namespace", "cluster", "pod"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		redisClusterStateMetric,
+		redisClusterSlotsAssignedMetric,
+		redisNodeUpMetric,
+		redisReplicationOffsetLagMetric,
+	)
+}
+
+// HealthChecker periodically polls every RedisCluster's cluster and node
+// state and caches the result so HTTP reads never block on Redis.
+type HealthChecker struct {
+	client.Client
+	interval time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]*ClusterHealth
+}
+
+// NewHealthChecker builds a HealthChecker that polls at the given interval.
+func NewHealthChecker(cl client.Client, interval time.Duration) *HealthChecker {
+	return &HealthChecker{
+		Client:   cl,
+		interval: interval,
+		cache:    make(map[string]*ClusterHealth),
+	}
+}
+
+// Start runs the polling loop until ctx is cancelled.
+func (hc *HealthChecker) Start(ctx context.Context) {
+	ticker := time.NewTicker(hc.interval)
+	defer ticker.Stop()
+	for {
+		hc.pollAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Get returns the last cached health snapshot for ns/name, or nil if none
+// has been collected yet.
+func (hc *HealthChecker) Get(ns, name string) *ClusterHealth {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.cache[healthCacheKey(ns, name)]
+}
+
+func healthCacheKey(ns, name string) string {
+	return ns + "/" + name
+}
+
+func (hc *HealthChecker) pollAll(ctx context.Context) {
+	var list redisv1beta1.RedisClusterList
+	if err := hc.List(ctx, &list); err != nil {
+		log.Error(err, "HealthChecker: failed to list redis clusters")
+		return
+	}
+	for i := range list.Items {
+		cr := &list.Items[i]
+		health := checkClusterHealth(cr)
+		hc.mu.Lock()
+		hc.cache[healthCacheKey(cr.Namespace, cr.ObjectMeta.Name)] = health
+		hc.mu.Unlock()
+		recordHealthMetrics(cr, health)
+	}
+}
+
+// checkClusterHealth runs CLUSTER INFO once for the cluster and PING/INFO
+// replication against every leader and follower pod to build a fresh
+// snapshot.
+func checkClusterHealth(cr *redisv1beta1.RedisCluster) *ClusterHealth {
+	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	info, err := fetchClusterInfo(cr)
+	if err != nil {
+		logger.Error(err, "CLUSTER INFO failed")
+	}
+	nodes, err := checkRedisCluster(cr)
+	if err != nil {
+		logger.Error(err, "Failed to inspect cluster state")
+	}
+
+	health := &ClusterHealth{
+		ClusterState: info["cluster_state"],
+		CheckedAt:    time.Now(),
+	}
+	if v, err := strconv.Atoi(info["cluster_slots_assigned"]); err == nil {
+		health.SlotsAssigned = v
+	}
+	if v, err := strconv.Atoi(info["cluster_slots_ok"]); err == nil {
+		health.ClusterSlotsOk = v
+	}
+
+	for _, role := range []string{"leader", "follower"} {
+		replicas := cr.Spec.GetReplicaCounts(role)
+		podPrefix := cr.ObjectMeta.Name + "-" + role + "-"
+		for podCount := 0; podCount <= int(replicas)-1; podCount++ {
+			health.Nodes = append(health.Nodes, pollNodeHealth(cr, podPrefix+strconv.Itoa(podCount), nodes))
+		}
+	}
+	return health
+}
+
+// fetchClusterInfo runs CLUSTER INFO against the first leader and returns
+// its key:value lines as a map.
+func fetchClusterInfo(cr *redisv1beta1.RedisCluster) (map[string]string, error) {
+	client := configureRedisClient(cr, cr.ObjectMeta.Name+"-leader-0")
+	cmd := redis.NewStringCmd("cluster", "info")
+	if err := client.Process(cmd); err != nil {
+		return nil, err
+	}
+	output, err := cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseInfoOutput(output), nil
+}
+
+// parseInfoOutput parses the "key:value\r\n" lines returned by CLUSTER INFO
+// and INFO into a map.
+func parseInfoOutput(output string) map[string]string {
+	info := make(map[string]string)
+	for _, line := range strings.Split(output, "\r\n") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		info[parts[0]] = parts[1]
+	}
+	return info
+}
+
+// pollNodeHealth runs PING and INFO replication against podName and matches
+// it to its CLUSTER NODES record to report slot ownership.
+func pollNodeHealth(cr *redisv1beta1.RedisCluster, podName string, nodes []ClusterNode) NodeHealth {
+	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	health := NodeHealth{
+		PodName: podName,
+		Addr:    getRedisServerIP(RedisDetails{PodName: podName, Namespace: cr.Namespace}),
+	}
+
+	client := configureRedisClient(cr, podName)
+	if err := client.Process(redis.NewStatusCmd("ping")); err != nil {
+		logger.Error(err, "PING failed", "Pod", podName)
+		return health
+	}
+	health.Up = true
+	health.Slots = slotsForIP(nodes, health.Addr)
+
+	infoCmd := redis.NewStringCmd("info", "replication")
+	if err := client.Process(infoCmd); err != nil {
+		logger.Error(err, "INFO replication failed", "Pod", podName)
+		return health
+	}
+	output, err := infoCmd.Result()
+	if err != nil {
+		logger.Error(err, "INFO replication failed", "Pod", podName)
+		return health
+	}
+
+	info := parseInfoOutput(output)
+	health.Role = info["role"]
+	if offset, err := strconv.ParseInt(info["master_repl_offset"], 10, 64); err == nil {
+		health.MasterReplOffset = offset
+	}
+	if health.Role == "slave" {
+		if offset, err := strconv.ParseInt(info["slave_repl_offset"], 10, 64); err == nil {
+			health.SlaveReplOffset = offset
+			health.ReplicationLag = health.MasterReplOffset - health.SlaveReplOffset
+		}
+	}
+	return health
+}
+
+// slotsForIP returns the slot ranges CLUSTER NODES reported for the node
+// whose address matches ip, formatted as "start-end" (or a bare slot for a
+// single-slot range). It returns an empty slice if the matching node owns no
+// slots, and nil if no node in nodes matches ip.
+func slotsForIP(nodes []ClusterNode, ip string) []string {
+	for _, node := range nodes {
+		host, _, err := net.SplitHostPort(node.Addr)
+		if err != nil || bracketIPv6(host) != ip {
+			continue
+		}
+		slots := make([]string, 0, len(node.Slots))
+		for _, s := range node.Slots {
+			if s.Start == s.End {
+				slots = append(slots, strconv.Itoa(s.Start))
+			} else {
+				slots = append(slots, fmt.Sprintf("%d-%d", s.Start, s.End))
+			}
+		}
+		return slots
+	}
+	return nil
+}
+
+// recordHealthMetrics exports a freshly collected snapshot as Prometheus
+// gauges served at /metrics.
+func recordHealthMetrics(cr *redisv1beta1.RedisCluster, health *ClusterHealth) {
+	state := 0.0
+	if health.ClusterState == "ok" {
+		state = 1
+	}
+	redisClusterStateMetric.WithLabelValues(cr.Namespace, cr.ObjectMeta.Name).Set(state)
+	redisClusterSlotsAssignedMetric.WithLabelValues(cr.Namespace, cr.ObjectMeta.Name).Set(float64(health.SlotsAssigned))
+
+	for _, node := range health.Nodes {
+		up := 0.0
+		if node.Up {
+			up = 1
+		}
+		redisNodeUpMetric.WithLabelValues(cr.Namespace, cr.ObjectMeta.Name, node.PodName).Set(up)
+		redisReplicationOffsetLagMetric.WithLabelValues(cr.Namespace, cr.ObjectMeta.Name, node.PodName).Set(float64(node.ReplicationLag))
+	}
+}
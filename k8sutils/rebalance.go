@@ -0,0 +1,388 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	redisv1beta1 "redis-operator/api/v1beta1"
+
+	"github.com/go-redis/redis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// slotMigrationBatchSize bounds how many keys are moved per MIGRATE call so
+// a single batch cannot stall the source node for an unbounded amount of time.
+const slotMigrationBatchSize = 100
+
+// rebalanceCheckInterval is how often RebalanceReconciler compares the live
+// leader count against Spec.GetReplicaCounts("leader") and triggers
+// RebalanceShards when they differ.
+const rebalanceCheckInterval = 30 * time.Second
+
+// SlotMove describes a single hash slot moving from one leader to another
+// as part of a rebalance.
+type SlotMove struct {
+	Slot          int    `json:"slot"`
+	SourceNodeID  string `json:"sourceNodeId"`
+	SourcePodName string `json:"sourcePodName"`
+	TargetNodeID  string `json:"targetNodeId"`
+	TargetPodName string `json:"targetPodName"`
+}
+
+// RebalancePlan is the set of slot moves required to go from the current
+// slot distribution to the target distribution for the desired leader count.
+type RebalancePlan struct {
+	Moves []SlotMove `json:"moves"`
+}
+
+// PlanRebalance computes the slot moves needed to reach a target
+// distribution for leaderCount leaders, without moving any data. It is used
+// both to answer dry-run requests and to drive the real migration.
+func PlanRebalance(cr *redisv1beta1.RedisCluster, leaderCount int) (*RebalancePlan, error) {
+	cm := NewClusterManager(cr)
+	target := ComputeSlotRanges(leaderCount)
+
+	targetNodeIDs := make([]string, leaderCount)
+	for i := 0; i < leaderCount; i++ {
+		podName := cr.ObjectMeta.Name + "-leader-" + strconv.Itoa(i)
+		nodeID, err := cm.NodeID(podName)
+		if err != nil {
+			return nil, fmt.Errorf("resolving node id for %s: %w", podName, err)
+		}
+		targetNodeIDs[i] = nodeID
+	}
+
+	currentOwner, err := currentSlotOwners(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &RebalancePlan{}
+	for _, assignment := range target {
+		targetNodeID := targetNodeIDs[assignment.LeaderIndex]
+		targetPodName := cr.ObjectMeta.Name + "-leader-" + strconv.Itoa(assignment.LeaderIndex)
+		for slot := assignment.Start; slot <= assignment.End; slot++ {
+			owner, ok := currentOwner[slot]
+			if ok && owner.nodeID == targetNodeID {
+				continue
+			}
+			move := SlotMove{
+				Slot:          slot,
+				TargetNodeID:  targetNodeID,
+				TargetPodName: targetPodName,
+			}
+			if ok {
+				move.SourceNodeID = owner.nodeID
+				move.SourcePodName = owner.podName
+			}
+			plan.Moves = append(plan.Moves, move)
+		}
+	}
+	return plan, nil
+}
+
+type slotOwner struct {
+	nodeID  string
+	podName string
+}
+
+// currentSlotOwners maps every currently-assigned slot to the leader pod and
+// node ID that owns it. It queries leader-0, which survives both scale-out
+// and scale-in, and reads every master's slots from its CLUSTER NODES view
+// cluster-wide - not just the pods within the (possibly already-shrunk)
+// target leader count - so a pre-shrink leader's slots are never mistaken
+// for "previously unassigned".
+func currentSlotOwners(cr *redisv1beta1.RedisCluster) (map[int]slotOwner, error) {
+	nodes, err := checkRedisCluster(cr)
+	if err != nil {
+		return nil, fmt.Errorf("cluster nodes failed: %w", err)
+	}
+
+	owners := make(map[int]slotOwner)
+	for _, node := range nodes {
+		if !node.IsMaster() {
+			continue
+		}
+		host, _, err := net.SplitHostPort(node.Addr)
+		if err != nil {
+			continue
+		}
+		podName, err := podNameForIP(cr, bracketIPv6(host))
+		if err != nil {
+			return nil, err
+		}
+		for _, slotRange := range node.Slots {
+			for slot := slotRange.Start; slot <= slotRange.End; slot++ {
+				owners[slot] = slotOwner{nodeID: node.ID, podName: podName}
+			}
+		}
+	}
+	return owners, nil
+}
+
+// podNameForIP resolves the pod backing ip by listing every pod in
+// cr.Namespace, since CLUSTER NODES reports only addresses. Listing rather
+// than guessing from cr.Spec's current leader count is what lets callers
+// discover pods that predate a scale-in (e.g. leader-3/leader-4 mid
+// shrink-to-3) that cr.Spec no longer names.
+func podNameForIP(cr *redisv1beta1.RedisCluster, ip string) (string, error) {
+	host := strings.Trim(ip, "[]")
+	pods, err := generateK8sClient().CoreV1().Pods(cr.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("listing pods in %s: %w", cr.Namespace, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP == host {
+			return pod.ObjectMeta.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no pod found with ip %s in namespace %s", host, cr.Namespace)
+}
+
+// migrateSlot moves every key in slot from the source to the target node in
+// batches, setting the slot to MIGRATING/IMPORTING on each side for the
+// duration of the move and NODE once it is drained.
+func migrateSlot(cr *redisv1beta1.RedisCluster, move SlotMove) error {
+	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	if move.SourceNodeID == "" {
+		// Slot was previously unassigned; just hand it to the target.
+		targetClient := configureRedisClient(cr, move.TargetPodName)
+		cmd := redis.NewStringCmd("cluster", "addslots", move.Slot)
+		return targetClient.Process(cmd)
+	}
+
+	sourceClient := configureRedisClient(cr, move.SourcePodName)
+	targetClient := configureRedisClient(cr, move.TargetPodName)
+	targetIP := getRedisServerIP(RedisDetails{PodName: move.TargetPodName, Namespace: cr.Namespace})
+
+	var authPass string
+	if cr.Spec.KubernetesConfig.ExistingPasswordSecret != nil {
+		pass, err := getRedisPassword(cr.Namespace, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Name, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Key)
+		if err != nil {
+			logger.Error(err, "Error in getting redis password")
+		}
+		authPass = pass
+	}
+
+	if err := targetClient.Process(redis.NewStringCmd("cluster", "setslot", move.Slot, "importing", move.SourceNodeID)); err != nil {
+		return fmt.Errorf("setslot importing on %s for slot %d: %w", move.TargetPodName, move.Slot, err)
+	}
+	if err := sourceClient.Process(redis.NewStringCmd("cluster", "setslot", move.Slot, "migrating", move.TargetNodeID)); err != nil {
+		return fmt.Errorf("setslot migrating on %s for slot %d: %w", move.SourcePodName, move.Slot, err)
+	}
+
+	for {
+		keysCmd := redis.NewStringSliceCmd("cluster", "getkeysinslot", move.Slot, slotMigrationBatchSize)
+		if err := sourceClient.Process(keysCmd); err != nil {
+			return fmt.Errorf("getkeysinslot %d on %s: %w", move.Slot, move.SourcePodName, err)
+		}
+		keys, err := keysCmd.Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			break
+		}
+
+		migrateArgs := make([]interface{}, 0, len(keys)+9)
+		migrateArgs = append(migrateArgs, "migrate", targetIP, "6379", "", 0, 5000)
+		if authPass != "" {
+			migrateArgs = append(migrateArgs, "auth", authPass)
+		}
+		migrateArgs = append(migrateArgs, "keys")
+		for _, key := range keys {
+			migrateArgs = append(migrateArgs, key)
+		}
+		if err := sourceClient.Process(redis.NewStatusCmd(migrateArgs...)); err != nil {
+			return fmt.Errorf("migrate batch for slot %d from %s to %s: %w", move.Slot, move.SourcePodName, move.TargetPodName, err)
+		}
+	}
+
+	if err := sourceClient.Process(redis.NewStringCmd("cluster", "setslot", move.Slot, "node", move.TargetNodeID)); err != nil {
+		return fmt.Errorf("setslot node on %s for slot %d: %w", move.SourcePodName, move.Slot, err)
+	}
+	if err := targetClient.Process(redis.NewStringCmd("cluster", "setslot", move.Slot, "node", move.TargetNodeID)); err != nil {
+		return fmt.Errorf("setslot node on %s for slot %d: %w", move.TargetPodName, move.Slot, err)
+	}
+	logger.Info("Slot migrated", "Slot", move.Slot, "From", move.SourcePodName, "To", move.TargetPodName)
+	return nil
+}
+
+// RebalanceShards drives the cluster from its current slot distribution to
+// the distribution implied by the current leader replica count, persisting
+// per-slot progress onto the CR status so a crashed Reconcile can resume
+// mid-migration instead of restarting the whole plan.
+func RebalanceShards(ctx context.Context, cl client.Client, cr *redisv1beta1.RedisCluster) error {
+	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	leaderCount := int(cr.Spec.GetReplicaCounts("leader"))
+
+	// A leader added by a scale-out has never gossiped with the existing
+	// cluster, so migrateSlot's CLUSTER SETSLOT against it would fail with
+	// "unknown node" - meet every current leader together first. This is a
+	// no-op for leaders already part of the cluster.
+	if err := NewClusterManager(cr).MeetNodes(); err != nil {
+		return fmt.Errorf("meeting leaders before rebalance: %w", err)
+	}
+
+	plan, err := PlanRebalance(cr, leaderCount)
+	if err != nil {
+		return err
+	}
+
+	for _, move := range plan.Moves {
+		if err := migrateSlot(cr, move); err != nil {
+			logger.Error(err, "Slot migration failed", "Slot", move.Slot)
+			return err
+		}
+		cr.Status.RebalanceProgress = append(cr.Status.RebalanceProgress, move.Slot)
+		if err := cl.Status().Update(ctx, cr); err != nil {
+			logger.Error(err, "Failed to persist rebalance progress", "Slot", move.Slot)
+			return err
+		}
+	}
+
+	return evacuateRemovedLeaders(cr)
+}
+
+// evacuateRemovedLeaders runs CLUSTER FORGET, from every surviving leader and
+// follower pod, for only the leader node IDs that removedLeaderNodeIDs
+// identifies as departed, so a subsequent StatefulSet shrink does not leave
+// stale cluster members behind without tearing down the nodes that are
+// staying.
+func evacuateRemovedLeaders(cr *redisv1beta1.RedisCluster) error {
+	leaderCount := int(cr.Spec.GetReplicaCounts("leader"))
+	nodes, err := checkRedisCluster(cr)
+	if err != nil {
+		return fmt.Errorf("cluster nodes failed: %w", err)
+	}
+
+	removedIDs := removedLeaderNodeIDs(cr, nodes, leaderCount)
+	if len(removedIDs) == 0 {
+		return nil
+	}
+
+	for _, role := range []string{"leader", "follower"} {
+		count := int(cr.Spec.GetReplicaCounts(role))
+		podPrefix := cr.ObjectMeta.Name + "-" + role + "-"
+		for podCount := 0; podCount < count; podCount++ {
+			client := configureRedisClient(cr, podPrefix+strconv.Itoa(podCount))
+			for _, nodeID := range removedIDs {
+				if err := client.Process(redis.NewStringCmd("cluster", "forget", nodeID)); err != nil {
+					return fmt.Errorf("cluster forget %s on %s: %w", nodeID, podPrefix+strconv.Itoa(podCount), err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// removedLeaderNodeIDs returns the master node IDs in nodes whose backing pod
+// index falls outside [0, leaderCount), or whose pod can no longer be
+// resolved at all (already deleted), since both are stale cluster members
+// once the leader StatefulSet has shrunk.
+func removedLeaderNodeIDs(cr *redisv1beta1.RedisCluster, nodes []ClusterNode, leaderCount int) []string {
+	prefix := cr.ObjectMeta.Name + "-leader-"
+	followerPrefix := cr.ObjectMeta.Name + "-follower-"
+	var removed []string
+	for _, node := range nodes {
+		if !node.IsMaster() {
+			continue
+		}
+		host, _, err := net.SplitHostPort(node.Addr)
+		if err != nil {
+			continue
+		}
+		podName, err := podNameForIP(cr, bracketIPv6(host))
+		if err != nil {
+			removed = append(removed, node.ID)
+			continue
+		}
+		idx, err := strconv.Atoi(strings.TrimPrefix(podName, prefix))
+		if strings.HasPrefix(podName, followerPrefix) {
+			// A follower pod promoted to master keeps its own pod index.
+			idx, err = strconv.Atoi(strings.TrimPrefix(podName, followerPrefix))
+		}
+		if err != nil || idx >= leaderCount {
+			removed = append(removed, node.ID)
+		}
+	}
+	return removed
+}
+
+// RebalanceReconciler watches every RedisCluster for a change in its live
+// leader count and automatically drives the cluster to the slot distribution
+// implied by Spec.GetReplicaCounts("leader"), so a scale-out/scale-in is safe
+// without an operator remembering to call POST /rebalance.
+type RebalanceReconciler struct {
+	client.Client
+	Interval time.Duration
+}
+
+// NewRebalanceReconciler builds a RebalanceReconciler polling at the given interval.
+func NewRebalanceReconciler(cl client.Client, interval time.Duration) *RebalanceReconciler {
+	return &RebalanceReconciler{
+		Client:   cl,
+		Interval: interval,
+	}
+}
+
+// Start runs the reconcile loop until ctx is cancelled.
+func (rr *RebalanceReconciler) Start(ctx context.Context) {
+	ticker := time.NewTicker(rr.Interval)
+	defer ticker.Stop()
+	for {
+		rr.reconcileAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (rr *RebalanceReconciler) reconcileAll(ctx context.Context) {
+	var list redisv1beta1.RedisClusterList
+	if err := rr.List(ctx, &list); err != nil {
+		log.Error(err, "RebalanceReconciler: failed to list redis clusters")
+		return
+	}
+	for i := range list.Items {
+		rr.reconcileOne(ctx, &list.Items[i])
+	}
+}
+
+// reconcileOne triggers RebalanceShards whenever the cluster's live leader
+// count (derived from CLUSTER NODES) differs from its desired leader count.
+func (rr *RebalanceReconciler) reconcileOne(ctx context.Context, cr *redisv1beta1.RedisCluster) {
+	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	nodes, err := checkRedisCluster(cr)
+	if err != nil {
+		logger.Error(err, "RebalanceReconciler: failed to inspect cluster state")
+		return
+	}
+
+	liveLeaders := 0
+	for _, node := range nodes {
+		if node.IsMaster() {
+			liveLeaders++
+		}
+	}
+	if liveLeaders == 0 {
+		return
+	}
+
+	desiredLeaders := int(cr.Spec.GetReplicaCounts("leader"))
+	if liveLeaders == desiredLeaders {
+		return
+	}
+
+	logger.Info("RebalanceReconciler: leader count changed, triggering rebalance", "Live", liveLeaders, "Desired", desiredLeaders)
+	if err := RebalanceShards(ctx, rr.Client, cr); err != nil {
+		logger.Error(err, "RebalanceReconciler: rebalance failed")
+	}
+}
@@ -0,0 +1,214 @@
+package k8sutils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	redisv1beta1 "redis-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// autoRecoverAnnotation gates RecoveryReconciler: only CRs carrying it set
+// to "true" are ever auto-recovered.
+const autoRecoverAnnotation = "redis.udaan.com/auto-recover"
+
+// recoveryCheckInterval is how often RecoveryReconciler re-evaluates quorum
+// health for every auto-recover-enabled RedisCluster.
+const recoveryCheckInterval = 15 * time.Second
+
+// recoveryAttemptWindow is the rolling window the circuit breaker counts
+// recovery attempts over.
+const recoveryAttemptWindow = 1 * time.Hour
+
+// defaultMaxRecoveryAttempts caps recovery attempts per recoveryAttemptWindow
+// when Spec.Recovery.MaxRecoveryAttempts is left at its zero value, so
+// enabling auto-recover never implies an unbounded circuit breaker.
+const defaultMaxRecoveryAttempts = 3
+
+// recoveryState tracks the in-flight auto-recovery bookkeeping for a single
+// RedisCluster: when quorum loss was first observed, how many graceful
+// attempts have been made since, and recent attempt timestamps for the
+// circuit breaker.
+type recoveryState struct {
+	failSince        time.Time
+	gracefulAttempts int
+	attempts         []time.Time
+}
+
+// RecoveryReconciler watches cluster quorum health and escalates through
+// graceful and then forced failover when more than half the masters are
+// failed or disconnected for longer than Spec.Recovery.QuorumLossTimeout.
+// It only acts on CRs annotated redis.udaan.com/auto-recover=true, and a
+// circuit breaker stops retries once Spec.Recovery.MaxRecoveryAttempts is
+// exceeded within recoveryAttemptWindow, to avoid destroying data on
+// flapping networks.
+type RecoveryReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	Interval time.Duration
+
+	mu    sync.Mutex
+	state map[types.NamespacedName]*recoveryState
+}
+
+// NewRecoveryReconciler builds a RecoveryReconciler polling at the given interval.
+func NewRecoveryReconciler(cl client.Client, recorder record.EventRecorder, interval time.Duration) *RecoveryReconciler {
+	return &RecoveryReconciler{
+		Client:   cl,
+		Recorder: recorder,
+		Interval: interval,
+		state:    make(map[types.NamespacedName]*recoveryState),
+	}
+}
+
+// Start runs the reconcile loop until ctx is cancelled.
+func (r *RecoveryReconciler) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		r.reconcileAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *RecoveryReconciler) reconcileAll(ctx context.Context) {
+	var list redisv1beta1.RedisClusterList
+	if err := r.List(ctx, &list); err != nil {
+		log.Error(err, "RecoveryReconciler: failed to list redis clusters")
+		return
+	}
+	for i := range list.Items {
+		cr := &list.Items[i]
+		if cr.Annotations[autoRecoverAnnotation] != "true" {
+			continue
+		}
+		r.reconcileOne(cr)
+	}
+}
+
+// reconcileOne evaluates current quorum health for cr and applies the
+// detect -> graceful -> force escalation policy, emitting an Event for
+// every state transition.
+func (r *RecoveryReconciler) reconcileOne(cr *redisv1beta1.RedisCluster) {
+	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	key := types.NamespacedName{Namespace: cr.Namespace, Name: cr.ObjectMeta.Name}
+
+	failedMasters, totalMasters, err := countFailedMasters(cr)
+	unreachable := err != nil
+	if unreachable {
+		logger.Error(err, "RecoveryReconciler: failed to inspect cluster state, assuming quorum lost")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st, ok := r.state[key]
+	if !ok {
+		st = &recoveryState{}
+		r.state[key] = st
+	}
+
+	// A total connectivity failure (leader-0 unreachable) must be treated as
+	// quorum lost, not "nothing configured" - otherwise the worst-case
+	// outage this reconciler exists to handle would never trigger recovery.
+	quorumLost := unreachable || (totalMasters > 0 && failedMasters*2 > totalMasters)
+	if !quorumLost {
+		if !st.failSince.IsZero() {
+			r.event(cr, corev1.EventTypeNormal, "Recovered", "cluster quorum restored, %d/%d masters healthy", totalMasters-failedMasters, totalMasters)
+		}
+		st.failSince = time.Time{}
+		st.gracefulAttempts = 0
+		return
+	}
+
+	if st.failSince.IsZero() {
+		st.failSince = time.Now()
+		r.event(cr, corev1.EventTypeWarning, "DetectedFail", "%d/%d masters failed or disconnected", failedMasters, totalMasters)
+		return
+	}
+
+	if time.Since(st.failSince) < cr.Spec.Recovery.QuorumLossTimeout.Duration {
+		return
+	}
+
+	if !r.allowAttempt(cr, st) {
+		logger.Info("RecoveryReconciler: circuit breaker open, skipping recovery attempt", "Cluster", cr.ObjectMeta.Name)
+		return
+	}
+
+	if st.gracefulAttempts < 2 {
+		st.gracefulAttempts++
+		r.event(cr, corev1.EventTypeWarning, "GracefulAttempted", "attempting graceful failover (attempt %d)", st.gracefulAttempts)
+		if err := ExecuteGracefulFailOverOperation(cr); err != nil {
+			logger.Error(err, "RecoveryReconciler: graceful failover failed")
+		}
+		return
+	}
+
+	r.event(cr, corev1.EventTypeWarning, "ForceAttempted", "graceful failover failed twice, escalating to forced failover")
+	if err := ExecuteFailoverOperation(cr); err != nil {
+		logger.Error(err, "RecoveryReconciler: forced failover failed")
+		return
+	}
+	st.gracefulAttempts = 0
+	st.failSince = time.Time{}
+}
+
+// allowAttempt applies the circuit breaker: no more than
+// Spec.Recovery.MaxRecoveryAttempts recovery attempts within
+// recoveryAttemptWindow. Callers must hold r.mu.
+func (r *RecoveryReconciler) allowAttempt(cr *redisv1beta1.RedisCluster, st *recoveryState) bool {
+	maxAttempts := cr.Spec.Recovery.MaxRecoveryAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxRecoveryAttempts
+	}
+
+	cutoff := time.Now().Add(-recoveryAttemptWindow)
+	fresh := st.attempts[:0]
+	for _, t := range st.attempts {
+		if t.After(cutoff) {
+			fresh = append(fresh, t)
+		}
+	}
+	if len(fresh) >= int(maxAttempts) {
+		st.attempts = fresh
+		return false
+	}
+	st.attempts = append(fresh, time.Now())
+	return true
+}
+
+func (r *RecoveryReconciler) event(cr *redisv1beta1.RedisCluster, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(cr, eventType, reason, messageFmt, args...)
+}
+
+// countFailedMasters returns the number of master nodes reporting fail or
+// disconnected state, and the total number of master nodes in the cluster.
+func countFailedMasters(cr *redisv1beta1.RedisCluster) (failed int, total int, err error) {
+	nodes, err := checkRedisCluster(cr)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, node := range nodes {
+		if !node.IsMaster() {
+			continue
+		}
+		total++
+		if node.IsFailed() || node.LinkState == "disconnected" {
+			failed++
+		}
+	}
+	return failed, total, nil
+}
@@ -2,9 +2,12 @@ package k8sutils
 
 import (
 	"context"
+	"fmt"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/tools/record"
 	"net/http"
 	redisv1beta1 "redis-operator/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,8 +41,54 @@ func forceRecoverCluster(ns string, cn string, cl client.Client) error {
 	return err
 }
 
+func fetchClusterCR(ns string, cn string, cl client.Client) (*redisv1beta1.RedisCluster, error) {
+	logger := generateRedisManagerLogger(ns, cn)
+	cr := &redisv1beta1.RedisCluster{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: cn, Namespace: ns}, cr); err != nil {
+		logger.Error(err, "CRD fetch error")
+		return nil, err
+	}
+	return cr, nil
+}
+
+func sentinelFailover(ns string, cn string, cl client.Client) error {
+	logger := generateRedisManagerLogger(ns, cn)
+	cr := &redisv1beta1.RedisCluster{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: cn, Namespace: ns}, cr); err != nil {
+		logger.Error(err, "CRD fetch error")
+		return err
+	}
+	err := ExecuteSentinelFailover(cr)
+	if err != nil {
+		logger.Error(err, "sentinel failover error")
+	}
+	return err
+}
+
 type RedisClusterCmdHandler struct {
 	client.Client
+
+	// Health is the background checker whose cached snapshots back the
+	// /health endpoint. It is lazily created by StartCmdServer if unset.
+	Health *HealthChecker
+
+	// Recorder emits the Events RecoveryReconciler records for auto-recovery
+	// state transitions.
+	Recorder record.EventRecorder
+
+	// Recovery is the background auto-recovery controller. It is lazily
+	// created by StartCmdServer if unset.
+	Recovery *RecoveryReconciler
+
+	// Sentinel is the background controller reconciling every CR's Sentinel
+	// StatefulSet and headless service. It is lazily created by
+	// StartCmdServer if unset.
+	Sentinel *SentinelReconciler
+
+	// Rebalance is the background controller that auto-triggers
+	// RebalanceShards when a CR's live leader count drifts from its desired
+	// count. It is lazily created by StartCmdServer if unset.
+	Rebalance *RebalanceReconciler
 }
 
 func send(w http.ResponseWriter, res map[string]string, status int) {
@@ -73,9 +122,85 @@ func (h *RedisClusterCmdHandler) forceResetClusterHandler(w http.ResponseWriter,
 	send(w, map[string]string{"status": "OK"}, http.StatusOK)
 }
 
+func (h *RedisClusterCmdHandler) sentinelFailoverHandler(w http.ResponseWriter, r *http.Request) {
+	ns := mux.Vars(r)["namespace"]
+	cn := mux.Vars(r)["clusterName"]
+	err := sentinelFailover(ns, cn, h.Client)
+	if err != nil {
+		sendError(w, err, http.StatusInternalServerError)
+		return
+	}
+	send(w, map[string]string{"status": "OK"}, http.StatusOK)
+}
+
+func (h *RedisClusterCmdHandler) rebalanceHandler(w http.ResponseWriter, r *http.Request) {
+	ns := mux.Vars(r)["namespace"]
+	cn := mux.Vars(r)["clusterName"]
+	cr, err := fetchClusterCR(ns, cn, h.Client)
+	if err != nil {
+		sendError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	leaderCount := int(cr.Spec.GetReplicaCounts("leader"))
+	plan, err := PlanRebalance(cr, leaderCount)
+	if err != nil {
+		sendError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if r.URL.Query().Get("dry-run") == "true" {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(plan)
+		return
+	}
+
+	if err := RebalanceShards(context.TODO(), h.Client, cr); err != nil {
+		sendError(w, err, http.StatusInternalServerError)
+		return
+	}
+	send(w, map[string]string{"status": "OK"}, http.StatusOK)
+}
+
+func (h *RedisClusterCmdHandler) healthHandler(w http.ResponseWriter, r *http.Request) {
+	ns := mux.Vars(r)["namespace"]
+	cn := mux.Vars(r)["clusterName"]
+	health := h.Health.Get(ns, cn)
+	if health == nil {
+		sendError(w, fmt.Errorf("no health data yet for %s/%s", ns, cn), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(health)
+}
+
 func (h *RedisClusterCmdHandler) StartCmdServer() {
+	if h.Health == nil {
+		h.Health = NewHealthChecker(h.Client, healthCheckInterval)
+	}
+	go h.Health.Start(context.Background())
+
+	if h.Recovery == nil {
+		h.Recovery = NewRecoveryReconciler(h.Client, h.Recorder, recoveryCheckInterval)
+	}
+	go h.Recovery.Start(context.Background())
+
+	if h.Sentinel == nil {
+		h.Sentinel = NewSentinelReconciler(h.Client, sentinelCheckInterval)
+	}
+	go h.Sentinel.Start(context.Background())
+
+	if h.Rebalance == nil {
+		h.Rebalance = NewRebalanceReconciler(h.Client, rebalanceCheckInterval)
+	}
+	go h.Rebalance.Start(context.Background())
+
 	router := mux.NewRouter().StrictSlash(true)
 	router.HandleFunc("/cluster/{namespace}/{clusterName}/reset", h.resetClusterHandler).Methods("POST")
 	router.HandleFunc("/cluster/{namespace}/{clusterName}/force-reset", h.forceResetClusterHandler).Methods("POST")
+	router.HandleFunc("/cluster/{namespace}/{clusterName}/sentinel-failover", h.sentinelFailoverHandler).Methods("POST")
+	router.HandleFunc("/cluster/{namespace}/{clusterName}/rebalance", h.rebalanceHandler).Methods("POST")
+	router.HandleFunc("/cluster/{namespace}/{clusterName}/health", h.healthHandler).Methods("GET")
+	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
 	http.ListenAndServe(":8090", router)
 }
@@ -3,7 +3,6 @@ package k8sutils
 import (
 	"bytes"
 	"context"
-	"encoding/csv"
 	"fmt"
 	"net"
 	"strconv"
@@ -44,71 +43,29 @@ func getRedisServerIP(redisInfo RedisDetails) string {
 	return redisIP
 }
 
-// ExecuteRedisClusterCommand will execute redis cluster creation command
+// ExecuteRedisClusterCommand will form the redis cluster by issuing native
+// CLUSTER MEET/ADDSLOTSRANGE calls over a pooled ClusterManager, instead of
+// shelling out to `redis-cli --cluster create` via pods/exec.
 func ExecuteRedisClusterCommand(cr *redisv1beta1.RedisCluster) {
 	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
-	replicas := cr.Spec.GetReplicaCounts("leader")
-	cmd := []string{"redis-cli", "--cluster", "create"}
-	for podCount := 0; podCount <= int(replicas)-1; podCount++ {
-		pod := RedisDetails{
-			PodName:   cr.ObjectMeta.Name + "-leader-" + strconv.Itoa(podCount),
-			Namespace: cr.Namespace,
-		}
-		cmd = append(cmd, getRedisServerIP(pod)+":6379")
+	cm := NewClusterManager(cr)
+	if err := cm.CreateCluster(); err != nil {
+		logger.Error(err, "Failed to create redis cluster")
 	}
-	cmd = append(cmd, "--cluster-yes")
-
-	if cr.Spec.KubernetesConfig.ExistingPasswordSecret != nil {
-		pass, err := getRedisPassword(cr.Namespace, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Name, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Key)
-		if err != nil {
-			logger.Error(err, "Error in getting redis password")
-		}
-		cmd = append(cmd, "-a")
-		cmd = append(cmd, pass)
-	}
-	cmd = append(cmd, getRedisTLSArgs(cr.Spec.TLS, cr.ObjectMeta.Name+"-leader-0")...)
-	logger.Info("Redis cluster creation command is", "Command", cmd)
-	executeCommand(cr, cmd, cr.ObjectMeta.Name+"-leader-0")
 }
 
-func getRedisTLSArgs(tlsConfig *redisv1beta1.TLSConfig, clientHost string) []string {
-	cmd := []string{}
-	if tlsConfig != nil {
-		cmd = append(cmd, "--tls")
-		cmd = append(cmd, "--cacert")
-		cmd = append(cmd, "/tls/ca.crt")
-		cmd = append(cmd, "-h")
-		cmd = append(cmd, clientHost)
-	}
-	return cmd
-}
-
-// createRedisReplicationCommand will create redis replication creation command
-func createRedisReplicationCommand(cr *redisv1beta1.RedisCluster, leaderPod RedisDetails, followerPod RedisDetails) []string {
-	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
-	cmd := []string{"redis-cli", "--cluster", "add-node"}
-	cmd = append(cmd, getRedisServerIP(followerPod)+":6379")
-	cmd = append(cmd, getRedisServerIP(leaderPod)+":6379")
-	cmd = append(cmd, "--cluster-slave")
-
-	if cr.Spec.KubernetesConfig.ExistingPasswordSecret != nil {
-		pass, err := getRedisPassword(cr.Namespace, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Name, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Key)
-		if err != nil {
-			logger.Error(err, "Error in getting redis password")
-		}
-		cmd = append(cmd, "-a")
-		cmd = append(cmd, pass)
-	}
-	cmd = append(cmd, getRedisTLSArgs(cr.Spec.TLS, leaderPod.PodName)...)
-	logger.Info("Redis replication creation command is", "Command", cmd)
-	return cmd
-}
-
-// ExecuteRedisReplicationCommand will execute the replication command
+// ExecuteRedisReplicationCommand will meet every follower pod into the
+// cluster and attach it to its matching leader via CLUSTER REPLICATE,
+// skipping pods already present in the cluster.
 func ExecuteRedisReplicationCommand(cr *redisv1beta1.RedisCluster) {
 	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	cm := NewClusterManager(cr)
 	replicas := cr.Spec.GetReplicaCounts("follower")
-	nodes := checkRedisCluster(cr)
+	nodes, err := checkRedisCluster(cr)
+	if err != nil {
+		logger.Error(err, "Failed to inspect cluster state, skipping replication")
+		return
+	}
 	for podCount := 0; podCount <= int(replicas)-1; podCount++ {
 		followerPod := RedisDetails{
 			PodName:   cr.ObjectMeta.Name + "-follower-" + strconv.Itoa(podCount),
@@ -119,41 +76,52 @@ func ExecuteRedisReplicationCommand(cr *redisv1beta1.RedisCluster) {
 			Namespace: cr.Namespace,
 		}
 		podIP := getRedisServerIP(followerPod)
-		if !checkRedisNodePresence(cr, nodes, podIP) {
-			logger.Info("Adding node to cluster.", "Node.IP", podIP, "Follower.Pod", followerPod)
-			cmd := createRedisReplicationCommand(cr, leaderPod, followerPod)
-			executeCommand(cr, cmd, cr.ObjectMeta.Name+"-leader-0")
-		} else {
+		if checkRedisNodePresence(cr, nodes, podIP) {
 			logger.Info("Skipping Adding node to cluster, already present.", "Follower.Pod", followerPod)
+			continue
+		}
+		logger.Info("Adding node to cluster.", "Node.IP", podIP, "Follower.Pod", followerPod)
+		if err := cm.MeetNode(followerPod.PodName); err != nil {
+			logger.Error(err, "Failed to meet follower into cluster", "Follower.Pod", followerPod)
+			continue
+		}
+		leaderID, err := cm.NodeID(leaderPod.PodName)
+		if err != nil {
+			logger.Error(err, "Failed to resolve leader node ID", "Leader.Pod", leaderPod)
+			continue
+		}
+		if err := cm.Replicate(followerPod.PodName, leaderID); err != nil {
+			logger.Error(err, "Failed to replicate follower", "Follower.Pod", followerPod, "Leader.Pod", leaderPod)
 		}
 	}
 }
 
-// checkRedisCluster will check the redis cluster have sufficient nodes or not
-func checkRedisCluster(cr *redisv1beta1.RedisCluster) [][]string {
+// checkRedisCluster will check the redis cluster have sufficient nodes or not.
+// It returns an error whenever CLUSTER NODES could not be issued or parsed, so
+// callers can tell "cluster unreachable" apart from "cluster has zero nodes".
+func checkRedisCluster(cr *redisv1beta1.RedisCluster) ([]ClusterNode, error) {
 	var client *redis.Client
 	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
 	client = configureRedisClient(cr, cr.ObjectMeta.Name+"-leader-0")
 	cmd := redis.NewStringCmd("cluster", "nodes")
-	err := client.Process(cmd)
-	if err != nil {
+	if err := client.Process(cmd); err != nil {
 		logger.Error(err, "Redis command failed with this error")
+		return nil, err
 	}
 
 	output, err := cmd.Result()
 	if err != nil {
 		logger.Error(err, "Redis command failed with this error")
+		return nil, err
 	}
 	logger.Info("Redis cluster nodes are listed", "Output", output)
 
-	csvOutput := csv.NewReader(strings.NewReader(output))
-	csvOutput.Comma = ' '
-	csvOutput.FieldsPerRecord = -1
-	csvOutputRecords, err := csvOutput.ReadAll()
+	nodes, err := ParseClusterNodes(output)
 	if err != nil {
 		logger.Error(err, "Error parsing Node Counts", "output", output)
+		return nil, err
 	}
-	return csvOutputRecords
+	return nodes, nil
 }
 
 // ExecuteFailoverOperation will execute redis failover operations
@@ -224,12 +192,18 @@ func executeClusterForget(cr *redisv1beta1.RedisCluster, role string) error {
 		if err != nil {
 			return err
 		}
-		if strings.Contains(nodesResult, "myself,slave") {
-			logger.Info("Slave, Disconnecting from master")
-			cmd := redis.NewStringCmd("CLUSTER", "FAILOVER", "TAKEOVER")
-			err = client.Process(cmd)
-			if err != nil {
-				return err
+		nodes, err := ParseClusterNodes(nodesResult)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if node.IsMyself() && node.IsSlave() {
+				logger.Info("Slave, Disconnecting from master")
+				cmd := redis.NewStringCmd("CLUSTER", "FAILOVER", "TAKEOVER")
+				if err = client.Process(cmd); err != nil {
+					return err
+				}
+				break
 			}
 		}
 		nodeIds, err := getNodeIds(nodesResult)
@@ -247,16 +221,13 @@ func executeClusterForget(cr *redisv1beta1.RedisCluster, role string) error {
 }
 
 func getNodeIds(nodesResult string) ([]string, error) {
-	csvOutput := csv.NewReader(strings.NewReader(nodesResult))
-	csvOutput.Comma = ' '
-	csvOutput.FieldsPerRecord = -1
-	csvOutputRecords, err := csvOutput.ReadAll()
+	nodes, err := ParseClusterNodes(nodesResult)
 	if err != nil {
 		return nil, err
 	}
-	var nodeIds []string
-	for i := 0; i < len(csvOutputRecords); i++ {
-		nodeIds = append(nodeIds, csvOutputRecords[i][0])
+	nodeIds := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeIds = append(nodeIds, node.ID)
 	}
 	return nodeIds, nil
 }
@@ -299,7 +270,11 @@ func executeFailoverCommand(cr *redisv1beta1.RedisCluster, role string) error {
 func CheckRedisNodeCount(cr *redisv1beta1.RedisCluster, nodeType string) int32 {
 	var redisNodeType string
 	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
-	clusterNodes := checkRedisCluster(cr)
+	clusterNodes, err := checkRedisCluster(cr)
+	if err != nil {
+		logger.Error(err, "Failed to inspect cluster state")
+		return 0
+	}
 	count := len(clusterNodes)
 
 	switch nodeType {
@@ -313,7 +288,7 @@ func CheckRedisNodeCount(cr *redisv1beta1.RedisCluster, nodeType string) int32 {
 	if nodeType != "" {
 		count = 0
 		for _, node := range clusterNodes {
-			if strings.Contains(node[2], redisNodeType) {
+			if node.hasFlag(redisNodeType) {
 				count++
 			}
 		}
@@ -327,11 +302,15 @@ func CheckRedisNodeCount(cr *redisv1beta1.RedisCluster, nodeType string) int32 {
 // CheckRedisClusterState will check the redis cluster state
 func CheckRedisClusterState(cr *redisv1beta1.RedisCluster) int {
 	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
-	clusterNodes := checkRedisCluster(cr)
+	clusterNodes, err := checkRedisCluster(cr)
+	if err != nil {
+		logger.Error(err, "Failed to inspect cluster state")
+		return 0
+	}
 	count := 0
 
 	for _, node := range clusterNodes {
-		if strings.Contains(node[2], "fail") || strings.Contains(node[7], "disconnected") {
+		if node.IsFailed() || node.LinkState == "disconnected" {
 			count++
 		}
 	}
@@ -433,18 +412,30 @@ func getContainerID(cr *redisv1beta1.RedisCluster, podName string) (int, *corev1
 }
 
 // checkRedisNodePresence will check if the redis node exist in cluster or not
-func checkRedisNodePresence(cr *redisv1beta1.RedisCluster, nodeList [][]string, nodeName string) bool {
+func checkRedisNodePresence(cr *redisv1beta1.RedisCluster, nodeList []ClusterNode, nodeName string) bool {
 	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
 	logger.Info("Checking if Node is in cluster", "Node", nodeName)
 	for _, node := range nodeList {
-		s := strings.Split(node[1], ":")
-		if s[0] == nodeName {
+		host, _, err := net.SplitHostPort(node.Addr)
+		if err != nil {
+			continue
+		}
+		if bracketIPv6(host) == nodeName {
 			return true
 		}
 	}
 	return false
 }
 
+// bracketIPv6 wraps host in brackets if it is an IPv6 address, mirroring
+// the bracketed form getRedisServerIP returns for IPv6 pod IPs.
+func bracketIPv6(host string) string {
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
+
 // generateRedisManagerLogger will generate logging interface for Redis operations
 func generateRedisManagerLogger(namespace, name string) logr.Logger {
 	reqLogger := log.WithValues("Request.RedisManager.Namespace", namespace, "Request.RedisManager.Name", name)
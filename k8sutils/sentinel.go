@@ -0,0 +1,246 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	redisv1beta1 "redis-operator/api/v1beta1"
+
+	"github.com/go-redis/redis"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// sentinelCheckInterval is how often SentinelReconciler re-applies the
+// Sentinel StatefulSet and headless service for every CR that configures one.
+const sentinelCheckInterval = 15 * time.Second
+
+// sentinelHeadlessServiceSuffix names the headless service fronting the
+// Sentinel StatefulSet's pods.
+const sentinelHeadlessServiceSuffix = "-sentinel-headless"
+
+// ReconcileSentinelStatefulset creates/updates the Sentinel StatefulSet and
+// its headless service for cr.Spec.SentinelConfig, mirroring how leader and
+// follower StatefulSets are reconciled elsewhere in the operator.
+func ReconcileSentinelStatefulset(cr *redisv1beta1.RedisCluster) error {
+	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	if cr.Spec.SentinelConfig == nil {
+		return nil
+	}
+	if err := generateSentinelStatefulSet(cr); err != nil {
+		logger.Error(err, "Cannot create sentinel statefulset")
+		return err
+	}
+	if err := generateSentinelHeadlessService(cr); err != nil {
+		logger.Error(err, "Cannot create sentinel headless service")
+		return err
+	}
+	return nil
+}
+
+// sentinelLabels returns the label set shared by the Sentinel StatefulSet,
+// its pods and its headless service.
+func sentinelLabels(cr *redisv1beta1.RedisCluster) map[string]string {
+	return map[string]string{
+		"app":                     cr.ObjectMeta.Name + "-sentinel",
+		"redis.udaan.com/cluster": cr.ObjectMeta.Name,
+		"redis.udaan.com/role":    "sentinel",
+	}
+}
+
+// generateSentinelStatefulSet creates or updates the StatefulSet running
+// redis-sentinel, configured to monitor cr.Spec.SentinelConfig.MasterName
+// with the configured quorum.
+func generateSentinelStatefulSet(cr *redisv1beta1.RedisCluster) error {
+	sentinelConfig := cr.Spec.SentinelConfig
+	name := cr.ObjectMeta.Name + "-sentinel"
+	labels := sentinelLabels(cr)
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: name + sentinelHeadlessServiceSuffix,
+			Replicas:    &sentinelConfig.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "sentinel",
+							Image: "redis:6.2-alpine",
+							Command: []string{
+								"sh", "-c",
+								fmt.Sprintf(
+									"echo \"sentinel monitor %s %s-leader-0.%s.%s.svc 6379 %d\" > /etc/sentinel.conf && "+
+										"echo \"sentinel down-after-milliseconds %s 5000\" >> /etc/sentinel.conf && "+
+										"redis-sentinel /etc/sentinel.conf",
+									sentinelConfig.MasterName, cr.ObjectMeta.Name, cr.ObjectMeta.Name, cr.Namespace, sentinelConfig.Quorum,
+									sentinelConfig.MasterName,
+								),
+							},
+							Ports: []corev1.ContainerPort{
+								{ContainerPort: 26379, Name: "sentinel"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	client := generateK8sClient()
+	existing, err := client.AppsV1().StatefulSets(cr.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		_, err = client.AppsV1().StatefulSets(cr.Namespace).Create(context.TODO(), sts, metav1.CreateOptions{})
+		return err
+	}
+
+	sts.ResourceVersion = existing.ResourceVersion
+	_, err = client.AppsV1().StatefulSets(cr.Namespace).Update(context.TODO(), sts, metav1.UpdateOptions{})
+	return err
+}
+
+// generateSentinelHeadlessService creates or updates the headless Service
+// backing the Sentinel StatefulSet's stable network identities.
+func generateSentinelHeadlessService(cr *redisv1beta1.RedisCluster) error {
+	name := cr.ObjectMeta.Name + "-sentinel"
+	labels := sentinelLabels(cr)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + sentinelHeadlessServiceSuffix,
+			Namespace: cr.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  labels,
+			Ports: []corev1.ServicePort{
+				{Name: "sentinel", Port: 26379, TargetPort: intstr.FromInt(26379)},
+			},
+		},
+	}
+
+	k8sClient := generateK8sClient()
+	existing, err := k8sClient.CoreV1().Services(cr.Namespace).Get(context.TODO(), svc.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		_, err = k8sClient.CoreV1().Services(cr.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+		return err
+	}
+
+	svc.ResourceVersion = existing.ResourceVersion
+	svc.Spec.ClusterIP = existing.Spec.ClusterIP
+	_, err = k8sClient.CoreV1().Services(cr.Namespace).Update(context.TODO(), svc, metav1.UpdateOptions{})
+	return err
+}
+
+// SentinelReconciler periodically re-applies the Sentinel StatefulSet and
+// headless service for every RedisCluster that configures one, so a manual
+// edit or deletion of either object is reverted on the next tick.
+type SentinelReconciler struct {
+	client.Client
+	Interval time.Duration
+}
+
+// NewSentinelReconciler builds a SentinelReconciler polling at the given interval.
+func NewSentinelReconciler(cl client.Client, interval time.Duration) *SentinelReconciler {
+	return &SentinelReconciler{
+		Client:   cl,
+		Interval: interval,
+	}
+}
+
+// Start runs the reconcile loop until ctx is cancelled.
+func (sr *SentinelReconciler) Start(ctx context.Context) {
+	ticker := time.NewTicker(sr.Interval)
+	defer ticker.Stop()
+	for {
+		sr.reconcileAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (sr *SentinelReconciler) reconcileAll(ctx context.Context) {
+	var list redisv1beta1.RedisClusterList
+	if err := sr.List(ctx, &list); err != nil {
+		log.Error(err, "SentinelReconciler: failed to list redis clusters")
+		return
+	}
+	for i := range list.Items {
+		cr := &list.Items[i]
+		if cr.Spec.SentinelConfig == nil {
+			continue
+		}
+		if err := ReconcileSentinelStatefulset(cr); err != nil {
+			log.Error(err, "SentinelReconciler: failed to reconcile sentinel", "Cluster", cr.ObjectMeta.Name)
+		}
+	}
+}
+
+// configureSentinelClient builds a *redis.SentinelClient against the first
+// Sentinel replica. Unlike a FailoverClient, which only ever talks to the
+// resolved master for ordinary data commands, a SentinelClient talks to the
+// Sentinel process itself and understands SENTINEL administrative commands.
+func configureSentinelClient(cr *redisv1beta1.RedisCluster) *redis.SentinelClient {
+	pod := RedisDetails{
+		PodName:   fmt.Sprintf("%s-sentinel-0", cr.ObjectMeta.Name),
+		Namespace: cr.Namespace,
+	}
+	options := &redis.Options{
+		Addr: getRedisServerIP(pod) + ":26379",
+	}
+	if cr.Spec.KubernetesConfig.ExistingPasswordSecret != nil {
+		logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+		pass, err := getRedisPassword(cr.Namespace, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Name, *cr.Spec.KubernetesConfig.ExistingPasswordSecret.Key)
+		if err != nil {
+			logger.Error(err, "Error in getting redis password")
+		}
+		options.Password = pass
+	}
+	return redis.NewSentinelClient(options)
+}
+
+// ExecuteSentinelFailover issues SENTINEL FAILOVER against a Sentinel
+// replica for cr.Spec.SentinelConfig.MasterName, triggering an orchestrated
+// master switch without the data-loss semantics of CLUSTER RESET.
+func ExecuteSentinelFailover(cr *redisv1beta1.RedisCluster) error {
+	logger := generateRedisManagerLogger(cr.Namespace, cr.ObjectMeta.Name)
+	if cr.Spec.SentinelConfig == nil {
+		return fmt.Errorf("sentinel is not configured for cluster %s/%s", cr.Namespace, cr.ObjectMeta.Name)
+	}
+
+	client := configureSentinelClient(cr)
+	defer client.Close()
+
+	if err := client.Failover(cr.Spec.SentinelConfig.MasterName).Err(); err != nil {
+		logger.Error(err, "SENTINEL FAILOVER failed", "Master", cr.Spec.SentinelConfig.MasterName)
+		return fmt.Errorf("sentinel failover for master %s failed: %w", cr.Spec.SentinelConfig.MasterName, err)
+	}
+
+	logger.Info("Sentinel failover triggered", "Master", cr.Spec.SentinelConfig.MasterName)
+	return nil
+}